@@ -0,0 +1,24 @@
+package smpp34
+
+// AlertNotification is sent by an SMSC to an ESME (no response expected)
+// to report that a subscriber has become available after a delivery
+// failure, e.g. after being out of coverage.
+type AlertNotification struct {
+	*genericPdu
+}
+
+func NewAlertNotification(header *Header, b []byte) (*AlertNotification, error) {
+	gp, err := newGenericPdu(header, []string{
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		ESME_ADDR_TON,
+		ESME_ADDR_NPI,
+		ESME_ADDR,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlertNotification{gp}, nil
+}
@@ -0,0 +1,39 @@
+package smpp34
+
+// Bind is the bind_transceiver/bind_transmitter/bind_receiver PDU used
+// to authenticate and open an SMPP session; all three share this body
+// layout and differ only in command_id.
+type Bind struct {
+	*genericPdu
+}
+
+func NewBind(header *Header, b []byte) (*Bind, error) {
+	gp, err := newGenericPdu(header, []string{
+		SYSTEM_ID,
+		PASSWORD,
+		SYSTEM_TYPE,
+		INTERFACE_VERSION,
+		ADDR_TON,
+		ADDR_NPI,
+		ADDRESS_RANGE,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bind{gp}, nil
+}
+
+// BindResp acknowledges a Bind with the SMSC's system_id.
+type BindResp struct {
+	*genericPdu
+}
+
+func NewBindResp(header *Header, b []byte) (*BindResp, error) {
+	gp, err := newGenericPdu(header, []string{SYSTEM_ID}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BindResp{gp}, nil
+}
@@ -0,0 +1,39 @@
+package smpp34
+
+// CancelSm cancels a previously submitted message that has not yet
+// been delivered.
+type CancelSm struct {
+	*genericPdu
+}
+
+func NewCancelSm(header *Header, b []byte) (*CancelSm, error) {
+	gp, err := newGenericPdu(header, []string{
+		SERVICE_TYPE,
+		MESSAGE_ID,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		DEST_ADDR_TON,
+		DEST_ADDR_NPI,
+		DESTINATION_ADDR,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CancelSm{gp}, nil
+}
+
+// CancelSmResp acknowledges a CancelSm. It has no mandatory fields.
+type CancelSmResp struct {
+	*genericPdu
+}
+
+func NewCancelSmResp(header *Header) (*CancelSmResp, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CancelSmResp{gp}, nil
+}
@@ -0,0 +1,36 @@
+package smpp34
+
+// The original bind_transceiver/submit_sm/deliver_sm/enquire_link
+// command set. See section 5.1.2.1 of the spec for the full table.
+const (
+	BIND_TRANSCEIVER      = 0x00000009
+	BIND_TRANSCEIVER_RESP = 0x80000009
+	SUBMIT_SM             = 0x00000004
+	SUBMIT_SM_RESP        = 0x80000004
+	DELIVER_SM            = 0x00000005
+	DELIVER_SM_RESP       = 0x80000005
+	ENQUIRE_LINK          = 0x00000015
+	ENQUIRE_LINK_RESP     = 0x80000015
+)
+
+// Additional SMPP v3.4 command IDs beyond the original set above. See
+// section 5.1.2.1 of the spec for the full table.
+const (
+	BIND_RECEIVER         = 0x00000001
+	BIND_RECEIVER_RESP    = 0x80000001
+	BIND_TRANSMITTER      = 0x00000002
+	BIND_TRANSMITTER_RESP = 0x80000002
+	QUERY_SM              = 0x00000003
+	QUERY_SM_RESP         = 0x80000003
+	UNBIND                = 0x00000006
+	UNBIND_RESP           = 0x80000006
+	REPLACE_SM            = 0x00000007
+	REPLACE_SM_RESP       = 0x80000007
+	CANCEL_SM             = 0x00000008
+	CANCEL_SM_RESP        = 0x80000008
+	OUTBIND               = 0x0000000B
+	GENERIC_NACK          = 0x80000000
+	ALERT_NOTIFICATION    = 0x00000102
+	DATA_SM               = 0x00000103
+	DATA_SM_RESP          = 0x80000103
+)
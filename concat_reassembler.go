@@ -0,0 +1,120 @@
+package smpp34
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const concatUdhIei = 0x00
+
+type concatKey struct {
+	src, dst string
+	ref      byte
+}
+
+type concatEntry struct {
+	total     byte
+	parts     map[byte][]byte
+	firstSeen time.Time
+}
+
+// ConcatReassembler reassembles deliver_sm PDUs that were split with a
+// UDH concatenation header (as produced by SubmitLongMessage), keyed on
+// (source_addr, destination_addr, reference). Incomplete messages are
+// dropped once they've been outstanding longer than timeout.
+type ConcatReassembler struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	pending map[concatKey]*concatEntry
+}
+
+// NewConcatReassembler creates a reassembler that discards parts of a
+// concatenated message that have sat incomplete for longer than
+// timeout. Call Expire periodically to enforce it.
+func NewConcatReassembler(timeout time.Duration) *ConcatReassembler {
+	return &ConcatReassembler{
+		timeout: timeout,
+		pending: make(map[concatKey]*concatEntry),
+	}
+}
+
+// Add feeds a deliver_sm into the reassembler. If it is not part of a
+// concatenated message, body is returned as-is with complete=true. If
+// it completes a concatenated message, the parts are concatenated in
+// sequence order and returned with complete=true.
+func (c *ConcatReassembler) Add(src, dst string, esmClass byte, shortMessage []byte) (body []byte, complete bool, err error) {
+	if esmClass&0x40 == 0 {
+		return shortMessage, true, nil
+	}
+
+	if len(shortMessage) < 6 {
+		return nil, false, errors.New("concatenated short_message shorter than UDH header")
+	}
+
+	udhl := shortMessage[0]
+	if int(udhl)+1 > len(shortMessage) {
+		return nil, false, errors.New("concatenated short_message UDH length out of range")
+	}
+
+	iei := shortMessage[1]
+	if iei != concatUdhIei {
+		// Not the 8-bit reference concatenation IE this package
+		// understands; hand the body back unmodified.
+		return shortMessage, true, nil
+	}
+
+	ref := shortMessage[3]
+	total := shortMessage[4]
+	seq := shortMessage[5]
+	part := shortMessage[1+int(udhl):]
+
+	if seq == 0 || total == 0 || seq > total {
+		return nil, false, errors.New("concatenated short_message has invalid sequence/total")
+	}
+
+	key := concatKey{src: src, dst: dst, ref: ref}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.pending[key]
+	if !ok {
+		entry = &concatEntry{total: total, parts: make(map[byte][]byte), firstSeen: time.Now()}
+		c.pending[key] = entry
+	}
+
+	entry.parts[seq] = part
+
+	if byte(len(entry.parts)) < entry.total {
+		return nil, false, nil
+	}
+
+	delete(c.pending, key)
+
+	assembled := make([]byte, 0, len(entry.parts)*maxConcatPartBytes)
+	for i := byte(1); i <= entry.total; i++ {
+		p, ok := entry.parts[i]
+		if !ok {
+			return nil, false, errors.New("concatenated message complete by count but missing a sequence number")
+		}
+		assembled = append(assembled, p...)
+	}
+
+	return assembled, true, nil
+}
+
+// Expire drops any in-flight concatenated messages older than the
+// configured timeout. Callers should invoke this periodically (e.g.
+// from the same ticker driving enquire_link keepalives).
+func (c *ConcatReassembler) Expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.pending {
+		if now.Sub(e.firstSeen) > c.timeout {
+			delete(c.pending, k)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package smpp34
+
+// DataSm is the data_sm PDU: a lighter-weight alternative to submit_sm
+// used for transaction-oriented messaging, typically paired with the
+// message_payload TLV instead of short_message.
+type DataSm struct {
+	*genericPdu
+}
+
+func NewDataSm(header *Header, b []byte) (*DataSm, error) {
+	gp, err := newGenericPdu(header, []string{
+		SERVICE_TYPE,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		DEST_ADDR_TON,
+		DEST_ADDR_NPI,
+		DESTINATION_ADDR,
+		ESM_CLASS,
+		REGISTERED_DELIVERY,
+		DATA_CODING,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataSm{gp}, nil
+}
+
+// DataSmResp acknowledges a DataSm.
+type DataSmResp struct {
+	*genericPdu
+}
+
+func NewDataSmResp(header *Header, b []byte) (*DataSmResp, error) {
+	gp, err := newGenericPdu(header, []string{MESSAGE_ID}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataSmResp{gp}, nil
+}
@@ -0,0 +1,49 @@
+package smpp34
+
+// DeliverSm is the deliver_sm PDU: an SMSC-originated delivery of a
+// mobile-originated message or a delivery receipt (see DeliveryReceipt).
+type DeliverSm struct {
+	*genericPdu
+}
+
+func NewDeliverSm(header *Header, b []byte) (*DeliverSm, error) {
+	gp, err := newGenericPdu(header, []string{
+		SERVICE_TYPE,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		DEST_ADDR_TON,
+		DEST_ADDR_NPI,
+		DESTINATION_ADDR,
+		ESM_CLASS,
+		PROTOCOL_ID,
+		PRIORITY_FLAG,
+		SCHEDULE_DELIVERY_TIME,
+		VALIDITY_PERIOD,
+		REGISTERED_DELIVERY,
+		REPLACE_IF_PRESENT_FLAG,
+		DATA_CODING,
+		SM_DEFAULT_MSG_ID,
+		SM_LENGTH,
+		SHORT_MESSAGE,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliverSm{gp}, nil
+}
+
+// DeliverSmResp acknowledges a DeliverSm. message_id is normally empty.
+type DeliverSmResp struct {
+	*genericPdu
+}
+
+func NewDeliverSmResp(header *Header, b []byte) (*DeliverSmResp, error) {
+	gp, err := newGenericPdu(header, []string{MESSAGE_ID}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliverSmResp{gp}, nil
+}
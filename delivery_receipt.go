@@ -0,0 +1,159 @@
+package smpp34
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tlvReceiptedMessageID = 0x001E
+	tlvMessageState       = 0x0427
+)
+
+// MessageState is the delivery state of a message, as reported by a
+// delivery receipt's stat field or message_state TLV.
+type MessageState int
+
+const (
+	MessageStateUnknown MessageState = iota
+	MessageStateEnroute
+	MessageStateDelivered
+	MessageStateExpired
+	MessageStateDeleted
+	MessageStateUndeliverable
+	MessageStateAccepted
+	MessageStateRejected
+)
+
+// message_state TLV values, per SMPP 3.4 section 5.2.28.
+var messageStateFromTLV = map[byte]MessageState{
+	1: MessageStateEnroute,
+	2: MessageStateDelivered,
+	3: MessageStateExpired,
+	4: MessageStateDeleted,
+	5: MessageStateUndeliverable,
+	6: MessageStateAccepted,
+	7: MessageStateUnknown,
+	8: MessageStateRejected,
+}
+
+var messageStateFromStat = map[string]MessageState{
+	"ENROUTE": MessageStateEnroute,
+	"DELIVRD": MessageStateDelivered,
+	"EXPIRED": MessageStateExpired,
+	"DELETED": MessageStateDeleted,
+	"UNDELIV": MessageStateUndeliverable,
+	"ACCEPTD": MessageStateAccepted,
+	"UNKNOWN": MessageStateUnknown,
+	"REJECTD": MessageStateRejected,
+}
+
+// receiptDateLayout is the "YYMMDDhhmm" layout used by submit date/done
+// date in a delivery receipt body.
+const receiptDateLayout = "0601021504"
+
+// DeliveryReceipt is the parsed form of a deliver_sm delivery receipt
+// (SMPP 3.4 section 4.7.1 / Appendix B).
+type DeliveryReceipt struct {
+	MessageID  string
+	Submitted  int
+	Delivered  int
+	SubmitDate time.Time
+	DoneDate   time.Time
+	State      MessageState
+	ErrorCode  string
+	Text       string
+}
+
+var receiptFields = []string{"id:", "sub:", "dlvrd:", "submit date:", "done date:", "stat:", "err:", "text:"}
+
+// DeliveryReceipt detects whether d is a delivery receipt (esm_class
+// bit 0x04 or 0x08 set) and, if so, parses its body. The textual body
+// is parsed first; the receipted_message_id and message_state TLVs,
+// when present, take precedence over the same information in the body.
+func (d *DeliverSm) DeliveryReceipt() (*DeliveryReceipt, bool, error) {
+	esmField, err := d.GetField(ESM_CLASS)
+	if err != nil {
+		return nil, false, err
+	}
+
+	esmClass := esmField.ByteArray()
+	if len(esmClass) != 1 || esmClass[0]&0x0C == 0 {
+		return nil, false, nil
+	}
+
+	smField, err := d.GetField(SHORT_MESSAGE)
+	if err != nil {
+		return nil, false, err
+	}
+
+	receipt, err := parseReceiptBody(string(smField.ByteArray()))
+	if err != nil {
+		return nil, true, err
+	}
+
+	for _, tlv := range d.TLVFields() {
+		switch tlv.Tag {
+		case tlvReceiptedMessageID:
+			receipt.MessageID = strings.TrimRight(string(tlv.Value), "\x00")
+		case tlvMessageState:
+			if len(tlv.Value) == 1 {
+				receipt.State = messageStateFromTLV[tlv.Value[0]]
+			}
+		}
+	}
+
+	return receipt, true, nil
+}
+
+func parseReceiptBody(body string) (*DeliveryReceipt, error) {
+	values := make(map[string]string, len(receiptFields))
+
+	for i, label := range receiptFields {
+		start := strings.Index(body, label)
+		if start == -1 {
+			continue
+		}
+		valueStart := start + len(label)
+
+		end := len(body)
+		for _, next := range receiptFields[i+1:] {
+			if idx := strings.Index(body[valueStart:], next); idx != -1 {
+				end = valueStart + idx
+				break
+			}
+		}
+
+		values[label] = strings.TrimSpace(body[valueStart:end])
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("smpp34: delivery receipt body did not match the expected format")
+	}
+
+	receipt := &DeliveryReceipt{
+		MessageID: values["id:"],
+		ErrorCode: values["err:"],
+		Text:      values["text:"],
+	}
+
+	if v, ok := values["sub:"]; ok {
+		receipt.Submitted, _ = strconv.Atoi(v)
+	}
+	if v, ok := values["dlvrd:"]; ok {
+		receipt.Delivered, _ = strconv.Atoi(v)
+	}
+	if v, ok := values["submit date:"]; ok {
+		receipt.SubmitDate, _ = time.Parse(receiptDateLayout, v)
+	}
+	if v, ok := values["done date:"]; ok {
+		receipt.DoneDate, _ = time.Parse(receiptDateLayout, v)
+	}
+	if v, ok := values["stat:"]; ok {
+		receipt.State = messageStateFromStat[v]
+	}
+
+	return receipt, nil
+}
@@ -0,0 +1,144 @@
+package smpp34
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func buildDeliverSm(t *testing.T, esmClass byte, shortMessage []byte, tlvs []*TLVField) *DeliverSm {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte(0x00)      // service_type
+	buf.WriteByte(0x00)      // source_addr_ton
+	buf.WriteByte(0x00)      // source_addr_npi
+	buf.WriteString("12345") // source_addr
+	buf.WriteByte(0x00)
+	buf.WriteByte(0x00)      // dest_addr_ton
+	buf.WriteByte(0x00)      // dest_addr_npi
+	buf.WriteString("67890") // destination_addr
+	buf.WriteByte(0x00)
+	buf.WriteByte(esmClass)
+	buf.WriteByte(0x00) // protocol_id
+	buf.WriteByte(0x00) // priority_flag
+	buf.WriteByte(0x00) // schedule_delivery_time
+	buf.WriteByte(0x00) // validity_period
+	buf.WriteByte(0x00) // registered_delivery
+	buf.WriteByte(0x00) // replace_if_present_flag
+	buf.WriteByte(0x00) // data_coding
+	buf.WriteByte(0x00) // sm_default_msg_id
+	buf.WriteByte(byte(len(shortMessage)))
+	buf.Write(shortMessage)
+
+	for _, tlv := range tlvs {
+		buf.Write(tlv.Writer())
+	}
+
+	header := NewPduHeader(0, DELIVER_SM, 0, 1)
+	d, err := NewDeliverSm(header, buf.Bytes())
+	if err != nil {
+		t.Fatalf("NewDeliverSm: %v", err)
+	}
+	return d
+}
+
+func TestDeliveryReceipt_StandardFormat(t *testing.T) {
+	body := "id:0123456789 sub:001 dlvrd:001 submit date:2607251200 done date:2607251201 stat:DELIVRD err:000 text:hello"
+	d := buildDeliverSm(t, 0x04, []byte(body), nil)
+
+	receipt, ok, err := d.DeliveryReceipt()
+	if err != nil {
+		t.Fatalf("DeliveryReceipt: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected ok=true for an esm_class 0x04 deliver_sm")
+	}
+
+	if receipt.MessageID != "0123456789" {
+		t.Errorf("MessageID = %q, want %q", receipt.MessageID, "0123456789")
+	}
+	if receipt.Submitted != 1 || receipt.Delivered != 1 {
+		t.Errorf("Submitted/Delivered = %d/%d, want 1/1", receipt.Submitted, receipt.Delivered)
+	}
+	if receipt.State != MessageStateDelivered {
+		t.Errorf("State = %v, want MessageStateDelivered", receipt.State)
+	}
+	if receipt.ErrorCode != "000" {
+		t.Errorf("ErrorCode = %q, want %q", receipt.ErrorCode, "000")
+	}
+	if receipt.Text != "hello" {
+		t.Errorf("Text = %q, want %q", receipt.Text, "hello")
+	}
+
+	wantDate := time.Date(2026, 7, 25, 12, 0, 0, 0, time.UTC)
+	if !receipt.SubmitDate.Equal(wantDate) {
+		t.Errorf("SubmitDate = %v, want %v", receipt.SubmitDate, wantDate)
+	}
+}
+
+func TestDeliveryReceipt_VendorVariantOmitsErrAndPads(t *testing.T) {
+	// Some vendors set esm_class's 0x08 bit instead of 0x04, pad fields
+	// with extra spaces, and omit err: entirely.
+	body := "id:ABCDEF   sub:1  dlvrd:1  submit date:2607251200  done date:2607251205  stat:EXPIRED  text:padded body"
+	d := buildDeliverSm(t, 0x08, []byte(body), nil)
+
+	receipt, ok, err := d.DeliveryReceipt()
+	if err != nil {
+		t.Fatalf("DeliveryReceipt: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected ok=true for an esm_class 0x08 deliver_sm")
+	}
+
+	if receipt.MessageID != "ABCDEF" {
+		t.Errorf("MessageID = %q, want %q", receipt.MessageID, "ABCDEF")
+	}
+	if receipt.State != MessageStateExpired {
+		t.Errorf("State = %v, want MessageStateExpired", receipt.State)
+	}
+	if receipt.ErrorCode != "" {
+		t.Errorf("ErrorCode = %q, want empty", receipt.ErrorCode)
+	}
+	if receipt.Text != "padded body" {
+		t.Errorf("Text = %q, want %q", receipt.Text, "padded body")
+	}
+}
+
+func TestDeliveryReceipt_TLVsOverrideBody(t *testing.T) {
+	body := "id:FROMBODY sub:001 dlvrd:001 submit date:2607251200 done date:2607251201 stat:DELIVRD err:000 text:hi"
+
+	tlvs := []*TLVField{
+		{tlvReceiptedMessageID, 7, append([]byte("FROMTLV"), 0x00)},
+		{tlvMessageState, 1, []byte{byte(3)}}, // EXPIRED
+	}
+
+	d := buildDeliverSm(t, 0x04, []byte(body), tlvs)
+
+	receipt, ok, err := d.DeliveryReceipt()
+	if err != nil {
+		t.Fatalf("DeliveryReceipt: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+
+	if receipt.MessageID != "FROMTLV" {
+		t.Errorf("MessageID = %q, want TLV value %q", receipt.MessageID, "FROMTLV")
+	}
+	if receipt.State != MessageStateExpired {
+		t.Errorf("State = %v, want MessageStateExpired (from TLV, not body's DELIVRD)", receipt.State)
+	}
+}
+
+func TestDeliveryReceipt_NotAReceipt(t *testing.T) {
+	d := buildDeliverSm(t, 0x00, []byte("plain inbound message"), nil)
+
+	_, ok, err := d.DeliveryReceipt()
+	if err != nil {
+		t.Fatalf("DeliveryReceipt: %v", err)
+	}
+	if ok {
+		t.Fatal("expected ok=false for a plain (non-receipt) deliver_sm")
+	}
+}
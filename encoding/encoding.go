@@ -0,0 +1,61 @@
+// Package encoding converts between Go strings and the byte encodings
+// named by SMPP's data_coding field (section 5.2.19 of the v3.4 spec).
+// It covers the subset that matters for text messaging: the GSM 7-bit
+// default alphabet, ASCII, Latin-1 (ISO-8859-1), and UCS-2, which via
+// surrogate pairs is also how emoji travel over SMPP.
+package encoding
+
+import "errors"
+
+// data_coding values this package knows how to handle.
+const (
+	GSM7   byte = 0x00
+	ASCII  byte = 0x01
+	Latin1 byte = 0x03
+	UCS2   byte = 0x08
+)
+
+var ErrUnsupportedDataCoding = errors.New("encoding: unsupported data_coding value")
+
+// Encode converts text to bytes for the given data_coding. If dc is
+// GSM7, ASCII or Latin1 but text contains runes outside that alphabet, Encode
+// auto-promotes to UCS2 and returns the data_coding actually used so
+// callers can set short_message/data_coding consistently.
+func Encode(text string, dc byte) ([]byte, byte, error) {
+	switch dc {
+	case GSM7:
+		if !isGSM7(text) {
+			return encodeUCS2(text), UCS2, nil
+		}
+		return encodeGSM7(text), GSM7, nil
+	case ASCII:
+		if !isASCII(text) {
+			return encodeUCS2(text), UCS2, nil
+		}
+		return []byte(text), ASCII, nil
+	case Latin1:
+		b, ok := encodeLatin1(text)
+		if !ok {
+			return encodeUCS2(text), UCS2, nil
+		}
+		return b, Latin1, nil
+	case UCS2:
+		return encodeUCS2(text), UCS2, nil
+	default:
+		return nil, 0, ErrUnsupportedDataCoding
+	}
+}
+
+// Decode converts data encoded per dc back to a Go string.
+func Decode(data []byte, dc byte) (string, error) {
+	switch dc {
+	case GSM7:
+		return decodeGSM7(data), nil
+	case ASCII, Latin1:
+		return decodeLatin1(data), nil
+	case UCS2:
+		return decodeUCS2(data)
+	default:
+		return "", ErrUnsupportedDataCoding
+	}
+}
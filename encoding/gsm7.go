@@ -0,0 +1,155 @@
+package encoding
+
+// gsm7Alphabet is the GSM 03.38 7-bit default alphabet, indexed by
+// septet value 0x00-0x7F.
+var gsm7Alphabet = [128]rune{
+	'@', '£', '$', '¥', 'è', 'é', 'ù', 'ì', 'ò', 'Ç', '\n', 'Ø', 'ø', '\r', 'Å', 'å',
+	'Δ', '_', 'Φ', 'Γ', 'Λ', 'Ω', 'Π', 'Ψ', 'Σ', 'Θ', 'Ξ', 0x1B, 'Æ', 'æ', 'ß', 'É',
+	' ', '!', '"', '#', '¤', '%', '&', '\'', '(', ')', '*', '+', ',', '-', '.', '/',
+	'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', ':', ';', '<', '=', '>', '?',
+	'¡', 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O',
+	'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z', 'Ä', 'Ö', 'Ñ', 'Ü', '§',
+	'¿', 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o',
+	'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z', 'ä', 'ö', 'ñ', 'ü', 'à',
+}
+
+// gsm7Extension maps the escape-prefixed (0x1B) extension table, keyed
+// by its septet, to the rune it represents.
+var gsm7Extension = map[byte]rune{
+	0x0A: '\f',
+	0x14: '^',
+	0x28: '{',
+	0x29: '}',
+	0x2F: '\\',
+	0x3C: '[',
+	0x3D: '~',
+	0x3E: ']',
+	0x40: '|',
+	0x65: '€',
+}
+
+var gsm7RuneToSeptet map[rune]byte
+var gsm7RuneToExtSeptet map[rune]byte
+
+func init() {
+	gsm7RuneToSeptet = make(map[rune]byte, len(gsm7Alphabet))
+	for i, r := range gsm7Alphabet {
+		if i == 0x1B {
+			continue // escape code, not a printable character
+		}
+		gsm7RuneToSeptet[r] = byte(i)
+	}
+
+	gsm7RuneToExtSeptet = make(map[rune]byte, len(gsm7Extension))
+	for septet, r := range gsm7Extension {
+		gsm7RuneToExtSeptet[r] = septet
+	}
+}
+
+func isGSM7(s string) bool {
+	for _, r := range s {
+		if _, ok := gsm7RuneToSeptet[r]; ok {
+			continue
+		}
+		if _, ok := gsm7RuneToExtSeptet[r]; ok {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 0x7F {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeGSM7 converts text to septets (escaping extension-table
+// characters) and packs them 8 septets into 7 octets per the spec.
+func encodeGSM7(text string) []byte {
+	septets := make([]byte, 0, len(text))
+	for _, r := range text {
+		if s, ok := gsm7RuneToSeptet[r]; ok {
+			septets = append(septets, s)
+			continue
+		}
+		if s, ok := gsm7RuneToExtSeptet[r]; ok {
+			septets = append(septets, 0x1B, s)
+		}
+	}
+	return packSeptets(septets)
+}
+
+func decodeGSM7(data []byte) string {
+	septets := unpackSeptets(data)
+
+	runes := make([]rune, 0, len(septets))
+	escape := false
+	for _, s := range septets {
+		if escape {
+			if r, ok := gsm7Extension[s]; ok {
+				runes = append(runes, r)
+			}
+			escape = false
+			continue
+		}
+		if s == 0x1B {
+			escape = true
+			continue
+		}
+		runes = append(runes, gsm7Alphabet[s&0x7F])
+	}
+	return string(runes)
+}
+
+func packSeptets(septets []byte) []byte {
+	packed := make([]byte, 0, (len(septets)*7+7)/8)
+
+	var buf uint16
+	var bits uint
+	for _, s := range septets {
+		buf |= uint16(s&0x7F) << bits
+		bits += 7
+		for bits >= 8 {
+			packed = append(packed, byte(buf))
+			buf >>= 8
+			bits -= 8
+		}
+	}
+	if bits > 0 {
+		packed = append(packed, byte(buf))
+	}
+	return packed
+}
+
+func unpackSeptets(data []byte) []byte {
+	septets := make([]byte, 0, (len(data)*8)/7)
+
+	var buf uint16
+	var bits uint
+	for _, b := range data {
+		buf |= uint16(b) << bits
+		bits += 8
+		for bits >= 7 {
+			septets = append(septets, byte(buf&0x7F))
+			buf >>= 7
+			bits -= 7
+		}
+	}
+
+	// When the septet count lands on an exact 8-septet (7-octet)
+	// boundary, the last septet is built entirely from the zero fill
+	// bits packSeptets used to pad the final octet, not from a real
+	// character. Drop it, as every SMPP stack does, accepting the rare
+	// ambiguity where the original text's last character really was
+	// '@' (septet value 0).
+	if n := len(septets); n > 0 && n%8 == 0 && septets[n-1] == 0 {
+		septets = septets[:n-1]
+	}
+
+	return septets
+}
@@ -0,0 +1,23 @@
+package encoding
+
+import "testing"
+
+func TestGSM7RoundTrip(t *testing.T) {
+	cases := []string{
+		"",
+		"A",
+		"AAAAAAA",  // 7 septets: packs into an exact 7-octet boundary
+		"AAAAAAAA", // 8 septets: also an exact 7-octet boundary
+		"Hello, World! This is a longer message to span a few octets.",
+	}
+
+	for _, text := range cases {
+		got, err := Decode(encodeGSM7(text), GSM7)
+		if err != nil {
+			t.Fatalf("Decode(encodeGSM7(%q)): %v", text, err)
+		}
+		if got != text {
+			t.Errorf("round trip %q: got %q", text, got)
+		}
+	}
+}
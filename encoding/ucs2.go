@@ -0,0 +1,29 @@
+package encoding
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+)
+
+func encodeUCS2(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	out := make([]byte, len(units)*2)
+	for i, u := range units {
+		binary.BigEndian.PutUint16(out[i*2:], u)
+	}
+	return out
+}
+
+func decodeUCS2(data []byte) (string, error) {
+	if len(data)%2 != 0 {
+		return "", errors.New("encoding: UCS-2 data has odd length")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,31 @@
+package smpp34
+
+// EnquireLink carries no mandatory fields; it is a keepalive ping either
+// peer can send at any time after binding.
+type EnquireLink struct {
+	*genericPdu
+}
+
+func NewEnquireLink(header *Header) (*EnquireLink, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnquireLink{gp}, nil
+}
+
+// EnquireLinkResp acknowledges an EnquireLink. Like EnquireLink it has
+// no mandatory fields.
+type EnquireLinkResp struct {
+	*genericPdu
+}
+
+func NewEnquireLinkResp(header *Header) (*EnquireLinkResp, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnquireLinkResp{gp}, nil
+}
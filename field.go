@@ -0,0 +1,41 @@
+package smpp34
+
+// Field is a single mandatory or TLV field value. The only thing the
+// rest of the package relies on is getting the raw wire bytes back out;
+// individual Field implementations may expose richer accessors (see
+// textField's Text() in short_message_field.go) on top of that.
+type Field interface {
+	ByteArray() []byte
+}
+
+// FixedField is a single-byte mandatory field (e.g. data_coding,
+// esm_class).
+type FixedField struct {
+	data byte
+}
+
+// NewFixedField wraps a single-byte field value.
+func NewFixedField(b byte) *FixedField {
+	return &FixedField{data: b}
+}
+
+func (f *FixedField) ByteArray() []byte {
+	return []byte{f.data}
+}
+
+// VariableField is a variable-length mandatory field: either a
+// NULL-terminated C-string (system_id, source_addr, ...) or a raw byte
+// run whose length is carried by a preceding field (short_message).
+type VariableField struct {
+	data []byte
+}
+
+// NewVariableField wraps a variable-length field value, wire bytes as-is
+// (including any trailing NULL terminator already present in b).
+func NewVariableField(b []byte) *VariableField {
+	return &VariableField{data: b}
+}
+
+func (f *VariableField) ByteArray() []byte {
+	return f.data
+}
@@ -0,0 +1,17 @@
+package smpp34
+
+// GenericNack is returned by either side when a PDU cannot be parsed or
+// otherwise fails basic validation (the header's Status carries the
+// error). It has no mandatory fields.
+type GenericNack struct {
+	*genericPdu
+}
+
+func NewGenericNack(header *Header) (*GenericNack, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenericNack{gp}, nil
+}
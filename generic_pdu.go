@@ -0,0 +1,95 @@
+package smpp34
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// genericPdu implements the common, order-driven parts of the Pdu
+// interface (field lookup, header access, serialization) for PDU types
+// whose mandatory fields are a flat, ordered list. Every PDU type in this
+// package embeds it; the length-prefixed short_message is handled by
+// create_pdu_fields' sm_length case.
+type genericPdu struct {
+	header              *Header
+	mandatoryFieldsList []string
+	fields              map[int]Field
+	tlvFields           []*TLVField
+}
+
+func (p *genericPdu) Fields() map[int]Field {
+	return p.fields
+}
+
+func (p *genericPdu) MandatoryFieldsList() []string {
+	return p.mandatoryFieldsList
+}
+
+func (p *genericPdu) GetHeader() *Header {
+	return p.header
+}
+
+func (p *genericPdu) TLVFields() []*TLVField {
+	return p.tlvFields
+}
+
+func (p *genericPdu) GetField(name string) (Field, error) {
+	for i, n := range p.mandatoryFieldsList {
+		if n == name {
+			return p.fields[i], nil
+		}
+	}
+
+	return nil, errors.New("Field not found: " + name)
+}
+
+func (p *genericPdu) Writer() []byte {
+	buf := new(bytes.Buffer)
+
+	for i := range p.mandatoryFieldsList {
+		if f, ok := p.fields[i]; ok {
+			buf.Write(f.ByteArray())
+		}
+	}
+
+	for _, t := range p.tlvFields {
+		buf.Write(t.Writer())
+	}
+
+	body := buf.Bytes()
+	length := uint32(16 + len(body))
+
+	out := new(bytes.Buffer)
+	out.Write(packUi32(length))
+	out.Write(packUi32(p.header.Id))
+	out.Write(packUi32(p.header.Status))
+	out.Write(packUi32(p.header.Sequence))
+	out.Write(body)
+
+	return out.Bytes()
+}
+
+// WriteTo writes the serialized PDU directly to w, satisfying io.WriterTo
+// so callers (notably PduWriter) don't have to hold the whole frame in a
+// local variable just to pass it along.
+func (p *genericPdu) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(p.Writer())
+	return int64(n), err
+}
+
+// newGenericPdu parses fieldNames out of b using create_pdu_fields and
+// wraps the result in a genericPdu ready to satisfy the Pdu interface.
+func newGenericPdu(header *Header, fieldNames []string, b []byte) (*genericPdu, error) {
+	fields, tlvs, err := create_pdu_fields(fieldNames, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return &genericPdu{
+		header:              header,
+		mandatoryFieldsList: fieldNames,
+		fields:              fields,
+		tlvFields:           tlvs,
+	}, nil
+}
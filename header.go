@@ -0,0 +1,22 @@
+package smpp34
+
+// Header is the fixed 16 byte SMPP PDU header: command_length,
+// command_id, command_status and sequence_number, in that wire order.
+type Header struct {
+	Length   uint32
+	Id       uint32
+	Status   uint32
+	Sequence uint32
+}
+
+// NewPduHeader builds a Header from its four wire fields. length may be
+// 0 when building a PDU to send, since genericPdu.Writer recomputes it
+// from the encoded body.
+func NewPduHeader(length, id, status, sequence uint32) *Header {
+	return &Header{
+		Length:   length,
+		Id:       id,
+		Status:   status,
+		Sequence: sequence,
+	}
+}
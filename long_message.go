@@ -0,0 +1,234 @@
+package smpp34
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+
+	"github.com/akonovalovdev/smpp-with-emojis/encoding"
+)
+
+// DataCoding mirrors the data_coding field values SubmitLongMessage
+// accepts; encoding/decoding itself lives in the encoding subpackage.
+type DataCoding byte
+
+const (
+	DataCodingDefault DataCoding = DataCoding(encoding.GSM7)
+	DataCodingLatin1  DataCoding = DataCoding(encoding.Latin1)
+	DataCodingUCS2    DataCoding = DataCoding(encoding.UCS2)
+)
+
+const messagePayloadTag = 0x0424
+
+// Most SMSCs in practice treat 140 octets as the usable short_message
+// budget regardless of data_coding (the field itself allows up to 254).
+// A concatenation UDH takes 6 of those, leaving 134 for text.
+const (
+	maxShortMessageBytes = 140
+	maxConcatPartBytes   = maxShortMessageBytes - 6
+)
+
+var concatRefCounter uint32
+
+func nextConcatReference() uint8 {
+	return uint8(atomic.AddUint32(&concatRefCounter, 1))
+}
+
+type longMessageConfig struct {
+	sourceTon, sourceNpi byte
+	destTon, destNpi     byte
+	useMessagePayload    bool
+	reference            *uint8
+}
+
+// LongMessageOption customizes SubmitLongMessage's defaults.
+type LongMessageOption func(*longMessageConfig)
+
+// WithMessagePayload forces the message_payload TLV (tag 0x0424) to be
+// used instead of UDH concatenation, for peers that support it.
+func WithMessagePayload() LongMessageOption {
+	return func(c *longMessageConfig) { c.useMessagePayload = true }
+}
+
+// WithConcatReference pins the UDH concatenation reference number
+// instead of letting SubmitLongMessage assign one.
+func WithConcatReference(ref uint8) LongMessageOption {
+	return func(c *longMessageConfig) { c.reference = &ref }
+}
+
+// WithSourceAddr sets the source_addr_ton/source_addr_npi on the
+// generated submit_sm PDUs. Defaults to 0 (unknown) for both.
+func WithSourceAddr(ton, npi byte) LongMessageOption {
+	return func(c *longMessageConfig) { c.sourceTon, c.sourceNpi = ton, npi }
+}
+
+// WithDestAddr sets the dest_addr_ton/dest_addr_npi on the generated
+// submit_sm PDUs. Defaults to 0 (unknown) for both.
+func WithDestAddr(ton, npi byte) LongMessageOption {
+	return func(c *longMessageConfig) { c.destTon, c.destNpi = ton, npi }
+}
+
+// SubmitLongMessage encodes text per dc (auto-promoting to UCS-2 when
+// text contains runes the chosen coding can't represent) and returns
+// the one or more submit_sm PDUs needed to deliver it: a single PDU
+// when it fits in one short_message, otherwise either a message_payload
+// TLV PDU or a UDH-concatenated sequence sharing a reference number.
+func SubmitLongMessage(sourceAddr, destAddr, text string, dc DataCoding, opts ...LongMessageOption) ([]Pdu, error) {
+	cfg := &longMessageConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	encoded, dc, err := encodeText(text, dc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encoded) <= maxShortMessageBytes {
+		pdu, err := buildSubmitSm(sourceAddr, destAddr, cfg, dc, 0x00, encoded, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []Pdu{pdu}, nil
+	}
+
+	if cfg.useMessagePayload {
+		tlv := &TLVField{messagePayloadTag, uint16(len(encoded)), encoded}
+		pdu, err := buildSubmitSm(sourceAddr, destAddr, cfg, dc, 0x00, []byte{}, []*TLVField{tlv})
+		if err != nil {
+			return nil, err
+		}
+		return []Pdu{pdu}, nil
+	}
+
+	ref := nextConcatReference()
+	if cfg.reference != nil {
+		ref = *cfg.reference
+	}
+
+	parts, err := splitEncodedText(text, dc, maxConcatPartBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > 255 {
+		return nil, errors.New("message too long: exceeds 255 concatenated parts")
+	}
+
+	pdus := make([]Pdu, 0, len(parts))
+	for i, part := range parts {
+		udh := []byte{0x05, 0x00, 0x03, ref, byte(len(parts)), byte(i + 1)}
+		body := append(udh, part...)
+
+		pdu, err := buildSubmitSm(sourceAddr, destAddr, cfg, dc, 0x40, body, nil)
+		if err != nil {
+			return nil, err
+		}
+		pdus = append(pdus, pdu)
+	}
+
+	return pdus, nil
+}
+
+// splitEncodedText splits text into the fewest parts whose encoding
+// under dc fits within maxBytes each, growing each part one rune at a
+// time and re-encoding to check. This keeps GSM7 septet packing and
+// UCS2 surrogate pairs intact (a naive slice of the fully-packed bytes
+// can fall mid-septet-boundary or mid-surrogate-pair and garble every
+// part after the cut).
+func splitEncodedText(text string, dc DataCoding, maxBytes int) ([][]byte, error) {
+	var parts [][]byte
+	var current []rune
+
+	flush := func() error {
+		if len(current) == 0 {
+			return nil
+		}
+		b, _, err := encoding.Encode(string(current), byte(dc))
+		if err != nil {
+			return err
+		}
+		parts = append(parts, b)
+		current = nil
+		return nil
+	}
+
+	for _, r := range text {
+		candidate := append(append([]rune{}, current...), r)
+		b, _, err := encoding.Encode(string(candidate), byte(dc))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) > maxBytes {
+			if len(current) == 0 {
+				return nil, errors.New("smpp34: a single character does not fit within a concatenated part")
+			}
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = []rune{r}
+			continue
+		}
+
+		current = candidate
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+// buildSubmitSm assembles a submit_sm PDU by encoding its fields to
+// wire format and handing them to NewSubmitSm, the same constructor
+// ParsePdu uses for an incoming submit_sm. This keeps SubmitLongMessage
+// producing a real *SubmitSm (as Session.Submit requires) without
+// needing to know SubmitSm's internal field layout.
+func buildSubmitSm(sourceAddr, destAddr string, cfg *longMessageConfig, dc DataCoding, esmClass byte, shortMessage []byte, tlvs []*TLVField) (*SubmitSm, error) {
+	header := NewPduHeader(0, SUBMIT_SM, 0, 0)
+	body := encodeSubmitSmBody(sourceAddr, destAddr, cfg, dc, esmClass, shortMessage, tlvs)
+
+	return NewSubmitSm(header, body)
+}
+
+func encodeSubmitSmBody(sourceAddr, destAddr string, cfg *longMessageConfig, dc DataCoding, esmClass byte, shortMessage []byte, tlvs []*TLVField) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(0x00) // service_type
+	buf.WriteByte(cfg.sourceTon)
+	buf.WriteByte(cfg.sourceNpi)
+	buf.WriteString(sourceAddr)
+	buf.WriteByte(0x00)
+	buf.WriteByte(cfg.destTon)
+	buf.WriteByte(cfg.destNpi)
+	buf.WriteString(destAddr)
+	buf.WriteByte(0x00)
+	buf.WriteByte(esmClass)
+	buf.WriteByte(0x00) // protocol_id
+	buf.WriteByte(0x00) // priority_flag
+	buf.WriteByte(0x00) // schedule_delivery_time
+	buf.WriteByte(0x00) // validity_period
+	buf.WriteByte(0x00) // registered_delivery
+	buf.WriteByte(0x00) // replace_if_present_flag
+	buf.WriteByte(byte(dc))
+	buf.WriteByte(0x00) // sm_default_msg_id
+	buf.WriteByte(byte(len(shortMessage)))
+	buf.Write(shortMessage)
+
+	for _, t := range tlvs {
+		buf.Write(t.Writer())
+	}
+
+	return buf.Bytes()
+}
+
+// encodeText encodes text per dc, auto-promoting to UCS-2 when dc
+// can't represent every rune in text (most notably emoji).
+func encodeText(text string, dc DataCoding) ([]byte, DataCoding, error) {
+	b, usedDc, err := encoding.Encode(text, byte(dc))
+	if err != nil {
+		return nil, 0, err
+	}
+	return b, DataCoding(usedDc), nil
+}
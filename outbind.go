@@ -0,0 +1,16 @@
+package smpp34
+
+// Outbind is sent by an SMSC to an ESME to signal that it should bind
+// as a receiver/transceiver, typically to deliver queued messages.
+type Outbind struct {
+	*genericPdu
+}
+
+func NewOutbind(header *Header, b []byte) (*Outbind, error) {
+	gp, err := newGenericPdu(header, []string{SYSTEM_ID, PASSWORD}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Outbind{gp}, nil
+}
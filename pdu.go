@@ -15,6 +15,7 @@ type Pdu interface {
 	GetHeader() *Header
 	TLVFields() []*TLVField
 	Writer() []byte
+	WriteTo(io.Writer) (int64, error)
 }
 
 func ParsePdu(data []byte) (Pdu, error) {
@@ -24,34 +25,12 @@ func ParsePdu(data []byte) (Pdu, error) {
 
 	header := ParsePduHeader(data[:16])
 
-	switch header.Id {
-	case SUBMIT_SM:
-		n, err := NewSubmitSm(header, data[16:])
-		return Pdu(n), err
-	case SUBMIT_SM_RESP:
-		n, err := NewSubmitSmResp(header, data[16:])
-		return Pdu(n), err
-	case DELIVER_SM:
-		n, err := NewDeliverSm(header, data[16:])
-		return Pdu(n), err
-	case DELIVER_SM_RESP:
-		n, err := NewDeliverSmResp(header, data[16:])
-		return Pdu(n), err
-	case BIND_TRANSCEIVER:
-		n, err := NewBind(header, data[16:])
-		return Pdu(n), err
-	case BIND_TRANSCEIVER_RESP:
-		n, err := NewBindResp(header, data[16:])
-		return Pdu(n), err
-	case ENQUIRE_LINK:
-		n, err := NewEnquireLink(header)
-		return Pdu(n), err
-	case ENQUIRE_LINK_RESP:
-		n, err := NewEnquireLinkResp(header)
-		return Pdu(n), err
-	default:
+	factory, ok := lookupPdu(header.Id)
+	if !ok {
 		return nil, errors.New("Unknown PDU Command ID: " + strconv.Itoa(int(header.Id)))
 	}
+
+	return factory(header, data[16:])
 }
 
 func ParsePduHeader(data []byte) *Header {
@@ -69,7 +48,7 @@ func create_pdu_fields(fieldNames []string, r *bytes.Buffer) (map[int]Field, []*
 	eof := false
 	for i, k := range fieldNames {
 		switch k {
-		case SERVICE_TYPE, SOURCE_ADDR, DESTINATION_ADDR, SCHEDULE_DELIVERY_TIME, VALIDITY_PERIOD, SYSTEM_ID, PASSWORD, SYSTEM_TYPE, ADDRESS_RANGE, MESSAGE_ID:
+		case SERVICE_TYPE, SOURCE_ADDR, DESTINATION_ADDR, SCHEDULE_DELIVERY_TIME, VALIDITY_PERIOD, SYSTEM_ID, PASSWORD, SYSTEM_TYPE, ADDRESS_RANGE, MESSAGE_ID, ESME_ADDR, FINAL_DATE:
 			t, err := r.ReadBytes(0x00)
 
 			if err == io.EOF {
@@ -79,7 +58,7 @@ func create_pdu_fields(fieldNames []string, r *bytes.Buffer) (map[int]Field, []*
 			}
 
 			fields[i] = NewVariableField(t)
-		case SOURCE_ADDR_TON, SOURCE_ADDR_NPI, DEST_ADDR_TON, DEST_ADDR_NPI, ESM_CLASS, PROTOCOL_ID, PRIORITY_FLAG, REGISTERED_DELIVERY, REPLACE_IF_PRESENT_FLAG, DATA_CODING, SM_DEFAULT_MSG_ID, INTERFACE_VERSION, ADDR_TON, ADDR_NPI:
+		case SOURCE_ADDR_TON, SOURCE_ADDR_NPI, DEST_ADDR_TON, DEST_ADDR_NPI, ESM_CLASS, PROTOCOL_ID, PRIORITY_FLAG, REGISTERED_DELIVERY, REPLACE_IF_PRESENT_FLAG, DATA_CODING, SM_DEFAULT_MSG_ID, INTERFACE_VERSION, ADDR_TON, ADDR_NPI, ESME_ADDR_TON, ESME_ADDR_NPI, MESSAGE_STATE, ERROR_CODE:
 			t, err := r.ReadByte()
 
 			if err == io.EOF {
@@ -112,6 +91,9 @@ func create_pdu_fields(fieldNames []string, r *bytes.Buffer) (map[int]Field, []*
 			}
 
 			fields[i+1] = NewVariableField(p)
+			if dcIdx, ok := indexOf(fieldNames[:i], DATA_CODING); ok {
+				fields[i+1] = decodeShortMessageField(fields[i+1], fields[dcIdx])
+			}
 		case SHORT_MESSAGE:
 			continue
 		}
@@ -0,0 +1,123 @@
+package smpp34
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultMaxPduLength is the largest command_length PduReader accepts
+// unless overridden with SetMaxLength. The SMPP 3.4 spec does not
+// mandate a hard cap, but 64KiB comfortably covers a message_payload
+// TLV and guards against a runaway length field wedging the reader.
+const DefaultMaxPduLength = 64 * 1024
+
+var pduBufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, DefaultMaxPduLength)
+		return &b
+	},
+}
+
+// PduReader decodes length-prefixed PDUs off a stream, typically a
+// net.Conn to an SMSC. It reuses a pooled staging buffer across reads
+// to avoid allocating a fresh byte slice per frame.
+type PduReader struct {
+	r         io.Reader
+	maxLength uint32
+}
+
+// NewPduReader wraps r, capping accepted PDUs at DefaultMaxPduLength.
+func NewPduReader(r io.Reader) *PduReader {
+	return &PduReader{r: r, maxLength: DefaultMaxPduLength}
+}
+
+// SetMaxLength overrides the command_length ceiling.
+func (pr *PduReader) SetMaxLength(n uint32) {
+	pr.maxLength = n
+}
+
+type deadlineReader interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// ReadPdu reads and parses the next frame, honoring ctx's deadline when
+// the underlying io.Reader supports SetReadDeadline (as net.Conn does).
+func (pr *PduReader) ReadPdu(ctx context.Context) (Pdu, error) {
+	if dr, ok := pr.r.(deadlineReader); ok {
+		if dl, ok := ctx.Deadline(); ok {
+			if err := dr.SetReadDeadline(dl); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := dr.SetReadDeadline(time.Time{}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return pr.readPdu()
+}
+
+func (pr *PduReader) readPdu() (Pdu, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(pr.r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+
+	length := unpackUi32(lenBuf[:])
+	if length < 16 {
+		return nil, errors.New("Invalid PDU. command_length under 16 bytes")
+	}
+	if length > pr.maxLength {
+		return nil, fmt.Errorf("Invalid PDU. command_length %d exceeds max %d", length, pr.maxLength)
+	}
+
+	bufPtr := pduBufPool.Get().(*[]byte)
+	defer pduBufPool.Put(bufPtr)
+
+	buf := *bufPtr
+	if cap(buf) < int(length) {
+		buf = make([]byte, length)
+	} else {
+		buf = buf[:length]
+	}
+
+	copy(buf[:4], lenBuf[:])
+	if _, err := io.ReadFull(pr.r, buf[4:length]); err != nil {
+		return nil, err
+	}
+
+	return ParsePdu(buf)
+}
+
+// PduWriter serializes PDUs directly to an io.Writer, typically a
+// net.Conn to an SMSC, without an intermediate []byte held by the
+// caller. Writes are serialized with an internal mutex so a PduWriter
+// can be shared across goroutines (as Session does) without
+// interleaving frames on the wire.
+type PduWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewPduWriter wraps w.
+func NewPduWriter(w io.Writer) *PduWriter {
+	return &PduWriter{w: w}
+}
+
+// WritePdu serializes p to the underlying writer.
+func (pw *PduWriter) WritePdu(p Pdu) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	_, err := p.WriteTo(pw.w)
+	return err
+}
@@ -0,0 +1,135 @@
+package smpp34
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+// enquireLinkFrame builds a minimal valid enquire_link frame (header
+// only, no mandatory fields) with the given sequence number.
+func enquireLinkFrame(seq uint32) []byte {
+	buf := new(bytes.Buffer)
+	buf.Write(packUi32(16))
+	buf.Write(packUi32(ENQUIRE_LINK))
+	buf.Write(packUi32(0))
+	buf.Write(packUi32(seq))
+	return buf.Bytes()
+}
+
+func TestPduReader_TornFrame(t *testing.T) {
+	frame := enquireLinkFrame(7)
+
+	// iotest.OneByteReader forces every Read to return at most one
+	// byte, exercising PduReader's use of io.ReadFull across multiple
+	// short reads for both the length prefix and the body.
+	r := NewPduReader(iotest.OneByteReader(bytes.NewReader(frame)))
+
+	pdu, err := r.ReadPdu(context.Background())
+	if err != nil {
+		t.Fatalf("ReadPdu returned error on torn frame: %v", err)
+	}
+	if pdu.GetHeader().Id != ENQUIRE_LINK {
+		t.Fatalf("got command id %#x, want %#x", pdu.GetHeader().Id, ENQUIRE_LINK)
+	}
+	if pdu.GetHeader().Sequence != 7 {
+		t.Fatalf("got sequence %d, want 7", pdu.GetHeader().Sequence)
+	}
+}
+
+func TestPduReader_PartialRead(t *testing.T) {
+	frame := enquireLinkFrame(1)
+
+	// Truncate mid-body: the length prefix promises 16 bytes but only
+	// 10 are actually available before EOF.
+	r := NewPduReader(bytes.NewReader(frame[:10]))
+
+	_, err := r.ReadPdu(context.Background())
+	if err == nil {
+		t.Fatal("expected an error reading a truncated frame, got nil")
+	}
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("got error %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestPduReader_OversizedLength(t *testing.T) {
+	buf := new(bytes.Buffer)
+	buf.Write(packUi32(1 << 20)) // 1MiB, far beyond any configured max
+	buf.Write(make([]byte, 12))  // rest of a well-formed header
+
+	r := NewPduReader(buf)
+	r.SetMaxLength(1024)
+
+	_, err := r.ReadPdu(context.Background())
+	if err == nil {
+		t.Fatal("expected an error reading an oversized frame, got nil")
+	}
+}
+
+// submitSmFrame builds a minimal submit_sm frame carrying sourceAddr in
+// its source_addr field, for exercising variable-length field parsing.
+func submitSmFrame(seq uint32, sourceAddr string) []byte {
+	body := new(bytes.Buffer)
+	body.WriteByte(0x00) // service_type
+	body.WriteByte(0x00) // source_addr_ton
+	body.WriteByte(0x00) // source_addr_npi
+	body.WriteString(sourceAddr)
+	body.WriteByte(0x00)
+	body.WriteByte(0x00) // dest_addr_ton
+	body.WriteByte(0x00) // dest_addr_npi
+	body.WriteString("dest")
+	body.WriteByte(0x00)
+	body.Write(make([]byte, 9)) // esm_class .. sm_default_msg_id
+	body.WriteByte(0x00)        // sm_length
+
+	buf := new(bytes.Buffer)
+	buf.Write(packUi32(uint32(16 + body.Len())))
+	buf.Write(packUi32(SUBMIT_SM))
+	buf.Write(packUi32(0))
+	buf.Write(packUi32(seq))
+	buf.Write(body.Bytes())
+	return buf.Bytes()
+}
+
+// TestPduReader_FieldsSurviveSubsequentRead guards against PduReader's
+// pooled staging buffer (see pduBufPool) leaking into the Fields of a
+// Pdu it already returned: create_pdu_fields must copy variable-length
+// field bytes out of that buffer rather than alias it, since the next
+// ReadPdu call reuses (and overwrites) the same backing array.
+func TestPduReader_FieldsSurviveSubsequentRead(t *testing.T) {
+	stream := append(submitSmFrame(1, "FIRSTADDR"), submitSmFrame(2, "SECONDADDR")...)
+	r := NewPduReader(bytes.NewReader(stream))
+
+	first, err := r.ReadPdu(context.Background())
+	if err != nil {
+		t.Fatalf("ReadPdu (first): %v", err)
+	}
+	sourceAddr, err := first.GetField(SOURCE_ADDR)
+	if err != nil {
+		t.Fatalf("GetField(SOURCE_ADDR): %v", err)
+	}
+	want := string(sourceAddr.ByteArray())
+
+	if _, err := r.ReadPdu(context.Background()); err != nil {
+		t.Fatalf("ReadPdu (second): %v", err)
+	}
+
+	if got := string(sourceAddr.ByteArray()); got != want {
+		t.Fatalf("first PDU's source_addr changed after second read: got %q, want %q", got, want)
+	}
+}
+
+func TestPduReader_RejectsShortLength(t *testing.T) {
+	buf := new(bytes.Buffer)
+	buf.Write(packUi32(4)) // below the 16-byte header minimum
+
+	r := NewPduReader(buf)
+
+	_, err := r.ReadPdu(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a command_length under 16 bytes, got nil")
+	}
+}
@@ -0,0 +1,40 @@
+package smpp34
+
+// QuerySm asks the SMSC for the current status of a previously
+// submitted message.
+type QuerySm struct {
+	*genericPdu
+}
+
+func NewQuerySm(header *Header, b []byte) (*QuerySm, error) {
+	gp, err := newGenericPdu(header, []string{
+		MESSAGE_ID,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuerySm{gp}, nil
+}
+
+// QuerySmResp carries the message's last known state.
+type QuerySmResp struct {
+	*genericPdu
+}
+
+func NewQuerySmResp(header *Header, b []byte) (*QuerySmResp, error) {
+	gp, err := newGenericPdu(header, []string{
+		MESSAGE_ID,
+		FINAL_DATE,
+		MESSAGE_STATE,
+		ERROR_CODE,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuerySmResp{gp}, nil
+}
@@ -0,0 +1,136 @@
+package smpp34
+
+import (
+	"sync"
+)
+
+// PduFactory builds a concrete Pdu from a parsed header and the
+// remaining body bytes (i.e. everything after the 16 byte header).
+type PduFactory func(*Header, []byte) (Pdu, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[uint32]PduFactory)
+)
+
+// RegisterPdu associates a command ID with a factory used to build the
+// corresponding Pdu in ParsePdu. It is safe to call concurrently and is
+// the mechanism by which vendor-specific or future PDU types can be
+// plugged in without forking this package.
+func RegisterPdu(id uint32, factory PduFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[id] = factory
+}
+
+func lookupPdu(id uint32) (PduFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[id]
+	return factory, ok
+}
+
+func init() {
+	RegisterPdu(SUBMIT_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewSubmitSm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(SUBMIT_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewSubmitSmResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(DELIVER_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewDeliverSm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(DELIVER_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewDeliverSmResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_TRANSCEIVER, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBind(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_TRANSCEIVER_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBindResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_TRANSMITTER, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBind(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_TRANSMITTER_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBindResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_RECEIVER, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBind(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(BIND_RECEIVER_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewBindResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(ENQUIRE_LINK, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewEnquireLink(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(ENQUIRE_LINK_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewEnquireLinkResp(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(UNBIND, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewUnbind(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(UNBIND_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewUnbindResp(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(GENERIC_NACK, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewGenericNack(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(OUTBIND, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewOutbind(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(ALERT_NOTIFICATION, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewAlertNotification(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(DATA_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewDataSm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(DATA_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewDataSmResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(QUERY_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewQuerySm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(QUERY_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewQuerySmResp(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(CANCEL_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewCancelSm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(CANCEL_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewCancelSmResp(h)
+		return Pdu(n), err
+	})
+	RegisterPdu(REPLACE_SM, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewReplaceSm(h, b)
+		return Pdu(n), err
+	})
+	RegisterPdu(REPLACE_SM_RESP, func(h *Header, b []byte) (Pdu, error) {
+		n, err := NewReplaceSmResp(h)
+		return Pdu(n), err
+	})
+}
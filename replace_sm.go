@@ -0,0 +1,42 @@
+package smpp34
+
+// ReplaceSm replaces the short message, validity period and delivery
+// flags of a previously submitted message that has not yet been
+// delivered.
+type ReplaceSm struct {
+	*genericPdu
+}
+
+func NewReplaceSm(header *Header, b []byte) (*ReplaceSm, error) {
+	gp, err := newGenericPdu(header, []string{
+		MESSAGE_ID,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		SCHEDULE_DELIVERY_TIME,
+		VALIDITY_PERIOD,
+		REGISTERED_DELIVERY,
+		SM_DEFAULT_MSG_ID,
+		SM_LENGTH,
+		SHORT_MESSAGE,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceSm{gp}, nil
+}
+
+// ReplaceSmResp acknowledges a ReplaceSm. It has no mandatory fields.
+type ReplaceSmResp struct {
+	*genericPdu
+}
+
+func NewReplaceSmResp(header *Header) (*ReplaceSmResp, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplaceSmResp{gp}, nil
+}
@@ -0,0 +1,454 @@
+package smpp34
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BindType selects which of the three SMPP bind PDUs Session uses to
+// establish the session.
+type BindType int
+
+const (
+	BindTransceiver BindType = iota
+	BindTransmitter
+	BindReceiver
+)
+
+// SessionConfig configures a Session.
+type SessionConfig struct {
+	Addr       string
+	SystemID   string
+	Password   string
+	SystemType string
+	BindType   BindType
+
+	// EnquireLinkInterval is how often Session pings an idle
+	// connection. Defaults to 30s.
+	EnquireLinkInterval time.Duration
+	// EnquireLinkTimeout is the grace period on top of
+	// EnquireLinkInterval: the connection is considered dead when, at an
+	// enquire_link tick, no enquire_link_resp has arrived for longer than
+	// EnquireLinkInterval+EnquireLinkTimeout. Defaults to
+	// EnquireLinkInterval.
+	EnquireLinkTimeout time.Duration
+	// MaxBackoff caps the exponential reconnect backoff. Defaults to 1m.
+	MaxBackoff time.Duration
+
+	Dial func(ctx context.Context, addr string) (net.Conn, error)
+}
+
+func (c *SessionConfig) setDefaults() {
+	if c.EnquireLinkInterval <= 0 {
+		c.EnquireLinkInterval = 30 * time.Second
+	}
+	if c.EnquireLinkTimeout <= 0 {
+		c.EnquireLinkTimeout = c.EnquireLinkInterval
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Minute
+	}
+	if c.Dial == nil {
+		var d net.Dialer
+		c.Dial = func(ctx context.Context, addr string) (net.Conn, error) {
+			return d.DialContext(ctx, "tcp", addr)
+		}
+	}
+}
+
+// Session is a bound SMPP client connection. It drives the
+// enquire_link keepalive, correlates submit_sm responses back to
+// callers by sequence_number, dispatches incoming deliver_sm to a
+// user-supplied handler, and transparently reconnects (with
+// exponential backoff) if the underlying connection drops.
+type Session struct {
+	cfg SessionConfig
+
+	seq uint32
+
+	mu        sync.Mutex
+	conn      net.Conn
+	writer    *PduWriter
+	pending   map[uint32]chan pendingResult
+	onDeliver func(*DeliverSm) (*DeliverSmResp, error)
+
+	pongCh chan struct{}
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// pendingResult is what a registered s.pending entry receives: either
+// the matching response Pdu, or err set if the connection that
+// registered the entry went down before a response arrived.
+type pendingResult struct {
+	pdu Pdu
+	err error
+}
+
+// NewSession creates a Session that has not yet connected. Call Run to
+// connect, bind, and serve until ctx is canceled or Close is called.
+func NewSession(cfg SessionConfig) *Session {
+	cfg.setDefaults()
+	return &Session{
+		cfg:     cfg,
+		pending: make(map[uint32]chan pendingResult),
+		pongCh:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// OnDeliver registers the handler invoked for each received deliver_sm.
+// Its return value is sent back as the deliver_sm_resp.
+func (s *Session) OnDeliver(fn func(*DeliverSm) (*DeliverSmResp, error)) {
+	s.mu.Lock()
+	s.onDeliver = fn
+	s.mu.Unlock()
+}
+
+// Run connects, binds, and serves the session until ctx is canceled or
+// Close is called, reconnecting with exponential backoff on failure.
+// It returns the error that ended the session for good (ctx.Err() or
+// the Close-triggered nil).
+func (s *Session) Run(ctx context.Context) error {
+	defer close(s.done)
+
+	backoff := time.Second
+	if backoff > s.cfg.MaxBackoff {
+		backoff = s.cfg.MaxBackoff
+	}
+	for {
+		err := s.runOnce(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stop:
+			return nil
+		default:
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stop:
+			return nil
+		}
+
+		backoff *= 2
+		if backoff > s.cfg.MaxBackoff {
+			backoff = s.cfg.MaxBackoff
+		}
+	}
+}
+
+// Close tears down the session and stops Run from reconnecting.
+func (s *Session) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+func (s *Session) runOnce(ctx context.Context) error {
+	conn, err := s.cfg.Dial(ctx, s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	reader := NewPduReader(conn)
+	writer := NewPduWriter(conn)
+
+	if err := s.bind(ctx, reader, writer); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.writer = writer
+	s.mu.Unlock()
+
+	err = s.serve(ctx, conn, reader)
+
+	// The connection that registered these pending entries is gone, so
+	// nothing will ever resolve them: fail them now rather than leaving
+	// any in-flight Submit/sendEnquireLink caller blocked forever (a
+	// context.Background() caller has no deadline of its own to save it).
+	s.mu.Lock()
+	s.conn = nil
+	s.writer = nil
+	s.mu.Unlock()
+	s.failPending(err)
+
+	return err
+}
+
+// failPending resolves every outstanding pending entry with err, then
+// clears the map so the next connection starts with a clean slate.
+func (s *Session) failPending(err error) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[uint32]chan pendingResult)
+	s.mu.Unlock()
+
+	for _, ch := range pending {
+		select {
+		case ch <- pendingResult{err: err}:
+		default:
+		}
+	}
+}
+
+func (s *Session) bind(ctx context.Context, reader *PduReader, writer *PduWriter) error {
+	header := NewPduHeader(0, s.bindCommandID(), 0, uint32(s.nextSequence()))
+
+	fields := map[int]Field{
+		0: NewVariableField(append([]byte(s.cfg.SystemID), 0x00)),
+		1: NewVariableField(append([]byte(s.cfg.Password), 0x00)),
+		2: NewVariableField(append([]byte(s.cfg.SystemType), 0x00)),
+		3: NewFixedField(0x34), // interface_version: SMPP 3.4
+		4: NewFixedField(0x00), // addr_ton
+		5: NewFixedField(0x00), // addr_npi
+		6: NewVariableField([]byte{0x00}),
+	}
+
+	bindPdu := &genericPdu{
+		header: header,
+		mandatoryFieldsList: []string{
+			SYSTEM_ID, PASSWORD, SYSTEM_TYPE, INTERFACE_VERSION, ADDR_TON, ADDR_NPI, ADDRESS_RANGE,
+		},
+		fields: fields,
+	}
+
+	if err := writer.WritePdu(bindPdu); err != nil {
+		return err
+	}
+
+	resp, err := reader.ReadPdu(ctx)
+	if err != nil {
+		return err
+	}
+
+	if resp.GetHeader().Status != 0 {
+		return errors.New("smpp34: bind rejected by peer")
+	}
+
+	return nil
+}
+
+func (s *Session) bindCommandID() uint32 {
+	switch s.cfg.BindType {
+	case BindTransmitter:
+		return BIND_TRANSMITTER
+	case BindReceiver:
+		return BIND_RECEIVER
+	default:
+		return BIND_TRANSCEIVER
+	}
+}
+
+func (s *Session) serve(ctx context.Context, conn net.Conn, reader *PduReader) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.readLoop(ctx, reader) }()
+
+	ticker := time.NewTicker(s.cfg.EnquireLinkInterval)
+	defer ticker.Stop()
+
+	lastPong := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.stop:
+			return nil
+		case err := <-errCh:
+			return err
+		case <-s.pongCh:
+			lastPong = time.Now()
+		case <-ticker.C:
+			if time.Since(lastPong) > s.cfg.EnquireLinkInterval+s.cfg.EnquireLinkTimeout {
+				return errors.New("smpp34: enquire_link timeout")
+			}
+			if err := s.sendEnquireLink(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendEnquireLink writes an enquire_link and, like Submit, registers
+// its sequence_number in s.pending so the matching enquire_link_resp
+// routed by readLoop can be correlated back here rather than silently
+// dropped. The wait happens in a goroutine so serve's select loop isn't
+// blocked waiting on a peer that may never answer; that goroutine exits
+// via ctx/s.stop, a real response, or failPending closing out the
+// entry once the connection that sent this enquire_link goes down (so
+// it doesn't leak across reconnects).
+func (s *Session) sendEnquireLink(ctx context.Context) error {
+	seq := s.nextSequence()
+	header := NewPduHeader(0, ENQUIRE_LINK, 0, seq)
+	pdu, err := NewEnquireLink(header)
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan pendingResult, 1)
+	s.mu.Lock()
+	s.pending[seq] = ch
+	w := s.writer
+	s.mu.Unlock()
+
+	if err := w.WritePdu(pdu); err != nil {
+		s.clearPending(seq)
+		return err
+	}
+
+	go func() {
+		defer s.clearPending(seq)
+
+		select {
+		case res := <-ch:
+			if res.err == nil {
+				select {
+				case s.pongCh <- struct{}{}:
+				default:
+				}
+			}
+		case <-ctx.Done():
+		case <-s.stop:
+		}
+	}()
+
+	return nil
+}
+
+func (s *Session) readLoop(ctx context.Context, reader *PduReader) error {
+	for {
+		pdu, err := reader.ReadPdu(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch p := pdu.(type) {
+		case *DeliverSm:
+			if err := s.handleDeliver(p); err != nil {
+				return err
+			}
+		case *EnquireLink:
+			if err := s.replyEnquireLink(p); err != nil {
+				return err
+			}
+		default:
+			s.resolvePending(pdu.GetHeader().Sequence, pdu)
+		}
+	}
+}
+
+func (s *Session) handleDeliver(p *DeliverSm) error {
+	s.mu.Lock()
+	handler := s.onDeliver
+	w := s.writer
+	s.mu.Unlock()
+
+	var resp *DeliverSmResp
+	if handler != nil {
+		var err error
+		resp, err = handler(p)
+		if err != nil {
+			return err
+		}
+	} else {
+		resp, _ = NewDeliverSmResp(NewPduHeader(0, DELIVER_SM_RESP, 0, p.GetHeader().Sequence), []byte{0x00})
+	}
+
+	if resp != nil {
+		return w.WritePdu(resp)
+	}
+	return nil
+}
+
+func (s *Session) replyEnquireLink(p *EnquireLink) error {
+	resp, err := NewEnquireLinkResp(NewPduHeader(0, ENQUIRE_LINK_RESP, 0, p.GetHeader().Sequence))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	w := s.writer
+	s.mu.Unlock()
+
+	return w.WritePdu(resp)
+}
+
+// Submit sends a submit_sm, assigning it the next sequence_number, and
+// waits for the matching submit_sm_resp.
+func (s *Session) Submit(ctx context.Context, pdu *SubmitSm) (*SubmitSmResp, error) {
+	seq := s.nextSequence()
+	pdu.GetHeader().Sequence = seq
+
+	ch := make(chan pendingResult, 1)
+	s.mu.Lock()
+	s.pending[seq] = ch
+	w := s.writer
+	s.mu.Unlock()
+	defer s.clearPending(seq)
+
+	if w == nil {
+		return nil, errors.New("smpp34: session not connected")
+	}
+	if err := w.WritePdu(pdu); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.err != nil {
+			return nil, res.err
+		}
+		resp, ok := res.pdu.(*SubmitSmResp)
+		if !ok {
+			return nil, errors.New("smpp34: unexpected response to submit_sm")
+		}
+		return resp, nil
+	}
+}
+
+func (s *Session) resolvePending(seq uint32, pdu Pdu) {
+	s.mu.Lock()
+	ch, ok := s.pending[seq]
+	s.mu.Unlock()
+
+	if ok {
+		ch <- pendingResult{pdu: pdu}
+	}
+}
+
+func (s *Session) clearPending(seq uint32) {
+	s.mu.Lock()
+	delete(s.pending, seq)
+	s.mu.Unlock()
+}
+
+func (s *Session) nextSequence() uint32 {
+	return atomic.AddUint32(&s.seq, 1)
+}
@@ -0,0 +1,231 @@
+package smpp34
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDial returns a Dial func that hands Session one side of an
+// in-memory net.Pipe, running serverFn on the other side so tests don't
+// need a real TCP listener.
+func fakeDial(serverFn func(conn net.Conn)) func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go serverFn(server)
+		return client, nil
+	}
+}
+
+// serveBind reads a bind PDU off conn and replies with a bind_resp
+// carrying status.
+func serveBind(conn net.Conn, status uint32) error {
+	r := NewPduReader(conn)
+	w := NewPduWriter(conn)
+
+	bindPdu, err := r.ReadPdu(context.Background())
+	if err != nil {
+		return err
+	}
+
+	respID := uint32(BIND_TRANSCEIVER_RESP)
+	switch bindPdu.GetHeader().Id {
+	case BIND_TRANSMITTER:
+		respID = BIND_TRANSMITTER_RESP
+	case BIND_RECEIVER:
+		respID = BIND_RECEIVER_RESP
+	}
+
+	resp, err := NewBindResp(NewPduHeader(0, respID, status, bindPdu.GetHeader().Sequence), append([]byte("test"), 0x00))
+	if err != nil {
+		return err
+	}
+	return w.WritePdu(resp)
+}
+
+// waitForConnected blocks until s has a live writer (i.e. has bound),
+// failing the test if that doesn't happen promptly.
+func waitForConnected(t *testing.T, s *Session) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		w := s.writer
+		s.mu.Unlock()
+		if w != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for session to connect")
+}
+
+func testSubmitSm() (*SubmitSm, error) {
+	body := encodeSubmitSmBody("source", "dest", &longMessageConfig{}, DataCodingDefault, 0x00, []byte("hi"), nil)
+	return NewSubmitSm(NewPduHeader(0, SUBMIT_SM, 0, 0), body)
+}
+
+func TestSession_SubmitRoundTrip(t *testing.T) {
+	done := make(chan struct{})
+	dial := fakeDial(func(conn net.Conn) {
+		defer close(done)
+
+		if err := serveBind(conn, 0); err != nil {
+			t.Errorf("server: serveBind: %v", err)
+			return
+		}
+
+		r := NewPduReader(conn)
+		w := NewPduWriter(conn)
+
+		submit, err := r.ReadPdu(context.Background())
+		if err != nil {
+			t.Errorf("server: ReadPdu(submit_sm): %v", err)
+			return
+		}
+
+		resp, err := NewSubmitSmResp(NewPduHeader(0, SUBMIT_SM_RESP, 0, submit.GetHeader().Sequence), append([]byte("msg-1"), 0x00))
+		if err != nil {
+			t.Errorf("server: NewSubmitSmResp: %v", err)
+			return
+		}
+		if err := w.WritePdu(resp); err != nil {
+			t.Errorf("server: WritePdu(submit_sm_resp): %v", err)
+		}
+	})
+
+	s := NewSession(SessionConfig{
+		Addr:                "fake",
+		SystemID:            "user",
+		Password:            "pass",
+		EnquireLinkInterval: time.Hour,
+		Dial:                dial,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- s.Run(ctx) }()
+
+	waitForConnected(t, s)
+
+	pdu, err := testSubmitSm()
+	if err != nil {
+		t.Fatalf("testSubmitSm: %v", err)
+	}
+
+	resp, err := s.Submit(context.Background(), pdu)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	msgID, err := resp.GetField(MESSAGE_ID)
+	if err != nil {
+		t.Fatalf("GetField(MESSAGE_ID): %v", err)
+	}
+	if got, want := string(msgID.ByteArray()), "msg-1\x00"; got != want {
+		t.Errorf("message_id = %q, want %q", got, want)
+	}
+
+	cancel()
+	<-runErr
+	<-done
+}
+
+// TestSession_SubmitFailsOnDisconnect guards the fix where an in-flight
+// Submit (called with context.Background(), which has no deadline of
+// its own) must not hang forever once the connection that's carrying it
+// drops.
+func TestSession_SubmitFailsOnDisconnect(t *testing.T) {
+	serverConnCh := make(chan net.Conn, 1)
+	dial := fakeDial(func(conn net.Conn) {
+		if err := serveBind(conn, 0); err != nil {
+			return
+		}
+		serverConnCh <- conn
+	})
+
+	s := NewSession(SessionConfig{
+		Addr:                "fake",
+		SystemID:            "user",
+		Password:            "pass",
+		EnquireLinkInterval: time.Hour,
+		Dial:                dial,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	waitForConnected(t, s)
+
+	pdu, err := testSubmitSm()
+	if err != nil {
+		t.Fatalf("testSubmitSm: %v", err)
+	}
+
+	submitErr := make(chan error, 1)
+	go func() {
+		_, err := s.Submit(context.Background(), pdu)
+		submitErr <- err
+	}()
+
+	serverConn := <-serverConnCh
+	serverConn.Close()
+
+	select {
+	case err := <-submitErr:
+		if err == nil {
+			t.Fatal("expected Submit to fail once its connection dropped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Submit did not unblock after its connection dropped (context.Background() caller left hanging)")
+	}
+}
+
+// TestSession_EnquireLinkTimeoutReconnects guards the keepalive/reconnect
+// path: a peer that stops answering enquire_link must have its
+// connection torn down and replaced rather than left idle forever.
+func TestSession_EnquireLinkTimeoutReconnects(t *testing.T) {
+	var dialCount int32
+	dial := fakeDial(func(conn net.Conn) {
+		atomic.AddInt32(&dialCount, 1)
+		if err := serveBind(conn, 0); err != nil {
+			return
+		}
+
+		// Drain enquire_link frames (so the client's writes don't block
+		// on this unbuffered pipe) but never reply, forcing a timeout.
+		r := NewPduReader(conn)
+		for {
+			if _, err := r.ReadPdu(context.Background()); err != nil {
+				return
+			}
+		}
+	})
+
+	s := NewSession(SessionConfig{
+		Addr:                "fake",
+		SystemID:            "user",
+		Password:            "pass",
+		EnquireLinkInterval: 10 * time.Millisecond,
+		EnquireLinkTimeout:  10 * time.Millisecond,
+		MaxBackoff:          10 * time.Millisecond,
+		Dial:                dial,
+	})
+
+	// Run's reconnect backoff starts at 1s capped by MaxBackoff (10ms
+	// here); the context needs to outlive one full
+	// enquire_link-timeout-then-backoff cycle.
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	s.Run(ctx)
+
+	if got := atomic.LoadInt32(&dialCount); got < 2 {
+		t.Fatalf("dial count = %d, want at least 2 (reconnect after enquire_link timeout)", got)
+	}
+}
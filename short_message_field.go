@@ -0,0 +1,44 @@
+package smpp34
+
+import "github.com/akonovalovdev/smpp-with-emojis/encoding"
+
+// textField decorates the raw short_message Field produced by
+// create_pdu_fields with its decoded text, so short_message is exposed
+// as a decoded string on every PDU whose mandatory fields include both
+// data_coding and short_message (submit_sm, deliver_sm) while still
+// answering ByteArray() (and anything else Field defines) for callers
+// that want raw bytes. replace_sm has no data_coding field, so its
+// short_message is left undecoded.
+type textField struct {
+	Field
+	text string
+	err  error
+}
+
+// Text returns the short_message decoded per the PDU's data_coding.
+func (f *textField) Text() (string, error) {
+	return f.text, f.err
+}
+
+// decodeShortMessageField wraps raw (the parsed short_message Field)
+// with its text decoded per dataCoding (the already-parsed data_coding
+// Field). If dataCoding isn't a single byte, raw is returned unwrapped
+// rather than failing the whole PDU parse over a cosmetic accessor.
+func decodeShortMessageField(raw Field, dataCoding Field) Field {
+	dc := dataCoding.ByteArray()
+	if len(dc) != 1 {
+		return raw
+	}
+
+	text, err := encoding.Decode(raw.ByteArray(), dc[0])
+	return &textField{Field: raw, text: text, err: err}
+}
+
+func indexOf(names []string, name string) (int, bool) {
+	for i, n := range names {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
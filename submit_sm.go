@@ -0,0 +1,49 @@
+package smpp34
+
+// SubmitSm is the submit_sm PDU: a mobile-terminated (or originated, for
+// vendor relay) short message submission.
+type SubmitSm struct {
+	*genericPdu
+}
+
+func NewSubmitSm(header *Header, b []byte) (*SubmitSm, error) {
+	gp, err := newGenericPdu(header, []string{
+		SERVICE_TYPE,
+		SOURCE_ADDR_TON,
+		SOURCE_ADDR_NPI,
+		SOURCE_ADDR,
+		DEST_ADDR_TON,
+		DEST_ADDR_NPI,
+		DESTINATION_ADDR,
+		ESM_CLASS,
+		PROTOCOL_ID,
+		PRIORITY_FLAG,
+		SCHEDULE_DELIVERY_TIME,
+		VALIDITY_PERIOD,
+		REGISTERED_DELIVERY,
+		REPLACE_IF_PRESENT_FLAG,
+		DATA_CODING,
+		SM_DEFAULT_MSG_ID,
+		SM_LENGTH,
+		SHORT_MESSAGE,
+	}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubmitSm{gp}, nil
+}
+
+// SubmitSmResp acknowledges a SubmitSm with the SMSC-assigned message_id.
+type SubmitSmResp struct {
+	*genericPdu
+}
+
+func NewSubmitSmResp(header *Header, b []byte) (*SubmitSmResp, error) {
+	gp, err := newGenericPdu(header, []string{MESSAGE_ID}, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubmitSmResp{gp}, nil
+}
@@ -0,0 +1,40 @@
+package smpp34
+
+import (
+	"errors"
+
+	"github.com/akonovalovdev/smpp-with-emojis/encoding"
+)
+
+// ShortMessageText returns the decoded short_message text of a
+// submit_sm or deliver_sm PDU. For a PDU lacking a short_message or a
+// data_coding field (data_sm, replace_sm) it returns the GetField
+// error. create_pdu_fields
+// already decodes short_message per data_coding as it parses (see
+// decodeShortMessageField), so this is normally just reading that
+// cached result back out; the direct decode below only runs as a
+// fallback for a Field that predates that wiring. Raw bytes remain
+// available via p.GetField(SHORT_MESSAGE).ByteArray() for callers that
+// need them (e.g. to detect a concatenation UDH before decoding).
+func ShortMessageText(p Pdu) (string, error) {
+	smField, err := p.GetField(SHORT_MESSAGE)
+	if err != nil {
+		return "", err
+	}
+
+	if tf, ok := smField.(interface{ Text() (string, error) }); ok {
+		return tf.Text()
+	}
+
+	dcField, err := p.GetField(DATA_CODING)
+	if err != nil {
+		return "", err
+	}
+
+	dc := dcField.ByteArray()
+	if len(dc) != 1 {
+		return "", errors.New("data_coding field is not a single byte")
+	}
+
+	return encoding.Decode(smField.ByteArray(), dc[0])
+}
@@ -0,0 +1,19 @@
+package smpp34
+
+// TLVField is an optional tag-length-value field appended after a PDU's
+// mandatory fields (SMPP 3.4 section 3.3).
+type TLVField struct {
+	Tag    uint16
+	Length uint16
+	Value  []byte
+}
+
+// Writer serializes the TLV back to wire format: a 2 byte tag, a 2 byte
+// length, then the value bytes.
+func (t *TLVField) Writer() []byte {
+	buf := make([]byte, 4+len(t.Value))
+	copy(buf[0:2], packUi16(t.Tag))
+	copy(buf[2:4], packUi16(uint16(len(t.Value))))
+	copy(buf[4:], t.Value)
+	return buf
+}
@@ -0,0 +1,31 @@
+package smpp34
+
+// Unbind carries no mandatory fields; it simply signals that the
+// session peer wishes to end the SMPP session.
+type Unbind struct {
+	*genericPdu
+}
+
+func NewUnbind(header *Header) (*Unbind, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Unbind{gp}, nil
+}
+
+// UnbindResp acknowledges an Unbind request. Like Unbind it has no
+// mandatory fields.
+type UnbindResp struct {
+	*genericPdu
+}
+
+func NewUnbindResp(header *Header) (*UnbindResp, error) {
+	gp, err := newGenericPdu(header, []string{}, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnbindResp{gp}, nil
+}